@@ -0,0 +1,100 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"io"
+	"sync/atomic"
+
+	"github.com/cockroachdb/pebble"
+)
+
+// engineVersion is bumped whenever a change to Stockfish's build or UCI
+// options could change WDL output for the same fen+depth, invalidating
+// previously cached entries.
+const engineVersion uint32 = 1
+
+// EvalCache persists FEN->WDL evaluations in an embedded KV store so that
+// repeated positions - shared openings, common endgames, or a rerun against
+// the same player at the same depth - skip re-analysis entirely.
+type EvalCache struct {
+	db       *pebble.DB
+	readonly bool
+	hits     int64
+	misses   int64
+}
+
+// OpenEvalCache opens (or creates) a pebble store at dir. When readonly is
+// true, Put becomes a no-op so concurrent benchmark runs can safely share a
+// cache directory.
+func OpenEvalCache(dir string, readonly bool) (*EvalCache, error) {
+	db, err := pebble.Open(dir, &pebble.Options{ReadOnly: readonly})
+	if err != nil {
+		return nil, err
+	}
+	return &EvalCache{db: db, readonly: readonly}, nil
+}
+
+// cacheKey is sha1(fen) || depth (4-byte little-endian), matching the
+// on-disk layout described in the cache design doc.
+func cacheKey(fen string, depth int) []byte {
+	h := sha1.New()
+	io.WriteString(h, fen)
+	sum := h.Sum(nil)
+	key := make([]byte, len(sum)+4)
+	copy(key, sum)
+	binary.LittleEndian.PutUint32(key[len(sum):], uint32(depth))
+	return key
+}
+
+// Get looks up a cached WDL for fen at depth. ok is false on a miss or a
+// stale entry written by a different engine version.
+func (c *EvalCache) Get(fen string, depth int) (w, d, l int, ok bool) {
+	val, closer, err := c.db.Get(cacheKey(fen, depth))
+	if err != nil {
+		atomic.AddInt64(&c.misses, 1)
+		return 0, 0, 0, false
+	}
+	defer closer.Close()
+
+	if len(val) != 16 || binary.LittleEndian.Uint32(val[12:16]) != engineVersion {
+		atomic.AddInt64(&c.misses, 1)
+		return 0, 0, 0, false
+	}
+
+	w = int(binary.LittleEndian.Uint32(val[0:4]))
+	d = int(binary.LittleEndian.Uint32(val[4:8]))
+	l = int(binary.LittleEndian.Uint32(val[8:12]))
+	atomic.AddInt64(&c.hits, 1)
+	return w, d, l, true
+}
+
+// Put writes back a WDL evaluation. It is a no-op on a readonly cache.
+func (c *EvalCache) Put(fen string, depth, w, d, l int) {
+	if c.readonly {
+		return
+	}
+	val := make([]byte, 16)
+	binary.LittleEndian.PutUint32(val[0:4], uint32(w))
+	binary.LittleEndian.PutUint32(val[4:8], uint32(d))
+	binary.LittleEndian.PutUint32(val[8:12], uint32(l))
+	binary.LittleEndian.PutUint32(val[12:16], engineVersion)
+	c.db.Set(cacheKey(fen, depth), val, pebble.NoSync)
+}
+
+// HitRate returns the fraction of Get calls that were satisfied from cache,
+// for the end-of-run summary.
+func (c *EvalCache) HitRate() float64 {
+	hits, misses := atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+func (c *EvalCache) Close() error {
+	if c.db == nil {
+		return nil
+	}
+	return c.db.Close()
+}
@@ -0,0 +1,550 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/notnil/chess"
+
+	"github.com/Bot-Rakshit/chess-bench/internal/events"
+	"github.com/Bot-Rakshit/chess-bench/internal/metrics"
+	"github.com/Bot-Rakshit/chess-bench/internal/stats"
+)
+
+const StockfishPath = "/opt/homebrew/bin/stockfish"
+
+type ArchivesResponse struct {
+	Archives []string `json:"archives"`
+}
+type GamesResponse struct {
+	Games []GameData `json:"games"`
+}
+type GameData struct {
+	PGN   string      `json:"pgn"`
+	White *PlayerData `json:"white"`
+	Black *PlayerData `json:"black"`
+}
+type PlayerData struct {
+	Username string `json:"username"`
+}
+
+type StockfishEngine struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	reader *bufio.Reader
+	depth  int
+}
+
+func NewStockfishEngine(threads, depth int) (*StockfishEngine, error) {
+	cmd := exec.Command(StockfishPath)
+	stdin, _ := cmd.StdinPipe()
+	stdout, _ := cmd.StdoutPipe()
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	// Use smaller buffer for lower latency (256 bytes like optimized Rust)
+	e := &StockfishEngine{
+		cmd:    cmd,
+		stdin:  stdin,
+		reader: bufio.NewReaderSize(stdout, 256),
+		depth:  depth,
+	}
+	e.send("uci")
+	e.waitForReady("uciok")
+	e.send(fmt.Sprintf("setoption name Threads value %d", threads))
+	e.send("setoption name UCI_ShowWDL value true")
+	e.send("isready")
+	e.waitForReady("readyok")
+	return e, nil
+}
+
+func (e *StockfishEngine) send(cmd string) {
+	io.WriteString(e.stdin, cmd+"\n")
+}
+
+// waitForReady waits for a token without parsing WDL. It reports false if
+// the read loop hit an I/O error before seeing the token, which callers
+// that care (e.g. EnginePool) can treat as the engine having died.
+func (e *StockfishEngine) waitForReady(token string) bool {
+	for {
+		line, err := e.reader.ReadString('\n')
+		if err != nil {
+			return false
+		}
+		if strings.Contains(line, token) {
+			return true
+		}
+	}
+}
+
+// analyze sends position and returns WDL, parsing inline without storing lines
+func (e *StockfishEngine) analyze(fen string) (int, int, int) {
+	e.send("position fen " + fen)
+	e.send(fmt.Sprintf("go depth %d", e.depth))
+
+	w, d, l := 333, 334, 333
+
+	for {
+		line, err := e.reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+
+		// Parse WDL inline - look for " wdl " pattern
+		if idx := strings.Index(line, " wdl "); idx != -1 {
+			// Extract the part after "wdl "
+			rest := line[idx+5:]
+			parts := strings.SplitN(rest, " ", 4)
+			if len(parts) >= 3 {
+				if v, err := strconv.Atoi(parts[0]); err == nil {
+					w = v
+				}
+				if v, err := strconv.Atoi(parts[1]); err == nil {
+					d = v
+				}
+				if v, err := strconv.Atoi(parts[2]); err == nil {
+					l = v
+				}
+			}
+		}
+
+		// Check for bestmove to exit
+		if strings.HasPrefix(line, "bestmove") {
+			break
+		}
+	}
+
+	return w, d, l
+}
+
+// timedAnalyze wraps analyze with a stockfish_analyze_seconds observation.
+func (e *StockfishEngine) timedAnalyze(fen string) (int, int, int) {
+	start := time.Now()
+	w, d, l := e.analyze(fen)
+	metrics.StockfishAnalyzeSeconds.Observe(time.Since(start).Seconds())
+	return w, d, l
+}
+
+func (e *StockfishEngine) quit() {
+	e.send("quit")
+	e.cmd.Wait()
+}
+
+// analyzeCached consults cache before analyzing, and writes the result back
+// on a miss. A nil cache (the --no-cache case) falls through to analyze.
+func (e *StockfishEngine) analyzeCached(cache *EvalCache, fen string) (int, int, int) {
+	metrics.PositionsEvaluatedTotal.Inc()
+	if cache == nil {
+		return e.timedAnalyze(fen)
+	}
+	if w, d, l, ok := cache.Get(fen, e.depth); ok {
+		return w, d, l
+	}
+	w, d, l := e.timedAnalyze(fen)
+	cache.Put(fen, e.depth, w, d, l)
+	return w, d, l
+}
+
+func wdlToProb(w, d, l int, isWhite bool) float64 {
+	if !isWhite {
+		w, l = l, w
+	}
+	return (float64(w) + float64(d)*0.5) / 1000.0
+}
+
+func calcAccuracy(before, after float64) float64 {
+	if after >= before {
+		return 100.0
+	}
+	acc := 100.0 * (1.0 - (before-after)*2.0)
+	if acc < 0 {
+		return 0
+	}
+	return acc
+}
+
+var httpClient = &http.Client{Timeout: 60 * time.Second}
+
+func fetchArchives(username string) ([]string, error) {
+	req, _ := http.NewRequest("GET", "https://api.chess.com/pub/player/"+username+"/games/archives", nil)
+	req.Header.Set("User-Agent", "ChessBenchmark/1.0")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var data ArchivesResponse
+	json.NewDecoder(resp.Body).Decode(&data)
+	return data.Archives, nil
+}
+
+func fetchGames(url string) ([]GameData, error) {
+	req, _ := http.NewRequest("GET", url, nil)
+	req.Header.Set("User-Agent", "ChessBenchmark/1.0")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var data GamesResponse
+	json.NewDecoder(resp.Body).Decode(&data)
+	return data.Games, nil
+}
+
+func analyzeGame(pe *pooledEngine, g GameData, username string, cache *EvalCache) (float64, float64, int, string, string, bool, time.Duration, time.Duration) {
+	if g.PGN == "" {
+		return 0, 0, 0, "", "", false, 0, 0
+	}
+
+	white, black := "", ""
+	if g.White != nil {
+		white = strings.ToLower(g.White.Username)
+	}
+	if g.Black != nil {
+		black = strings.ToLower(g.Black.Username)
+	}
+	target := strings.ToLower(username)
+	if white != target && black != target {
+		return 0, 0, 0, "", "", false, 0, 0
+	}
+
+	// Parse PGN using notnil/chess (this is the slow part we can't optimize without changing library)
+	parseStart := time.Now()
+	pgnGame, err := chess.PGN(strings.NewReader(g.PGN))
+	if err != nil {
+		return 0, 0, 0, "", "", false, 0, 0
+	}
+	game := chess.NewGame(pgnGame)
+	moves := game.Moves()
+	parseTime := time.Since(parseStart)
+	metrics.PgnParseSeconds.Observe(parseTime.Seconds())
+	metrics.GamesParsedTotal.Inc()
+
+	if len(moves) == 0 {
+		return 0, 0, 0, "", "", false, 0, 0
+	}
+
+	// Pre-allocate slices
+	whiteAcc := make([]float64, 0, len(moves)/2+1)
+	blackAcc := make([]float64, 0, len(moves)/2+1)
+
+	analyzeStart := time.Now()
+	pos := chess.NewGame()
+	pw, pd, pl := pe.analyzeCached(cache, pos.Position().String())
+	pe.analyses++
+
+	for _, mv := range moves {
+		isWhite := pos.Position().Turn() == chess.White
+		pos.Move(mv)
+
+		cw, cd, cl := pe.analyzeCached(cache, pos.Position().String())
+		pe.analyses++
+		acc := calcAccuracy(wdlToProb(pw, pd, pl, isWhite), wdlToProb(cw, cd, cl, isWhite))
+
+		if isWhite {
+			whiteAcc = append(whiteAcc, acc)
+		} else {
+			blackAcc = append(blackAcc, acc)
+		}
+		pw, pd, pl = cw, cd, cl
+	}
+	analyzeTime := time.Since(analyzeStart)
+
+	wa, ba := 0.0, 0.0
+	if len(whiteAcc) > 0 {
+		for _, a := range whiteAcc {
+			wa += a
+		}
+		wa /= float64(len(whiteAcc))
+	}
+	if len(blackAcc) > 0 {
+		for _, a := range blackAcc {
+			ba += a
+		}
+		ba /= float64(len(blackAcc))
+	}
+
+	return wa, ba, len(whiteAcc) + len(blackAcc), white, black, true, parseTime, analyzeTime
+}
+
+func main() {
+	username := flag.String("username", "hikaru", "Chess.com username")
+	maxGames := flag.Int("games", 1000, "Max games")
+	workers := flag.Int("workers", 4, "Number of workers")
+	sfThreads := flag.Int("threads", 1, "SF threads per worker")
+	depth := flag.Int("depth", 4, "Stockfish depth")
+	cacheDir := flag.String("cache-dir", "", "Directory for the persistent FEN->WDL cache (disabled if empty)")
+	cacheReadonly := flag.Bool("cache-readonly", false, "Open the cache read-only (no writes on miss)")
+	noCache := flag.Bool("no-cache", false, "Disable the FEN->WDL cache even if --cache-dir is set")
+	metricsAddr := flag.String("metrics-addr", "", "Serve Prometheus /metrics and /debug/pprof/* on this address (disabled if empty)")
+	jsonOutput := flag.Bool("json", false, "Emit an NDJSON event stream on stdout instead of human-readable text")
+	flag.Parse()
+
+	if *metricsAddr != "" {
+		go metrics.Serve(*metricsAddr)
+	}
+
+	if flag.NArg() >= 1 {
+		*username = flag.Arg(0)
+	}
+	if flag.NArg() >= 2 {
+		fmt.Sscanf(flag.Arg(1), "%d", maxGames)
+	}
+
+	if *jsonOutput {
+		events.Emit(true, struct {
+			Type      string `json:"type"`
+			Username  string `json:"username"`
+			MaxGames  int    `json:"max_games"`
+			Workers   int    `json:"workers"`
+			SFThreads int    `json:"sf_threads"`
+			Depth     int    `json:"depth"`
+		}{"config", *username, *maxGames, *workers, *sfThreads, *depth})
+	} else {
+		fmt.Println("Go Chess Benchmark")
+		fmt.Println(strings.Repeat("=", 50))
+		fmt.Printf("Username: %s\n", *username)
+		fmt.Printf("Max games: %d\n", *maxGames)
+		fmt.Printf("Workers: %d\n", *workers)
+		fmt.Printf("SF threads/worker: %d\n", *sfThreads)
+		fmt.Printf("Total CPU: %d\n", *workers**sfThreads)
+		fmt.Printf("Depth: %d\n", *depth)
+		fmt.Println()
+	}
+
+	var cache *EvalCache
+	if *cacheDir != "" && !*noCache {
+		var err error
+		cache, err = OpenEvalCache(*cacheDir, *cacheReadonly)
+		if err != nil {
+			fmt.Printf("Warning: could not open cache at %s: %v (continuing uncached)\n", *cacheDir, err)
+		} else {
+			defer cache.Close()
+		}
+	}
+
+	if !*jsonOutput {
+		fmt.Println("Fetching archives...")
+	}
+	fetchStart := time.Now()
+	archives, _ := fetchArchives(*username)
+	for i, j := 0, len(archives)-1; i < j; i, j = i+1, j-1 {
+		archives[i], archives[j] = archives[j], archives[i]
+	}
+
+	var allGames []GameData
+	for _, url := range archives {
+		if len(allGames) >= *maxGames {
+			break
+		}
+		games, _ := fetchGames(url)
+		parts := strings.Split(url, "/")
+		metrics.GamesFetchedTotal.Add(float64(len(games)))
+		if *jsonOutput {
+			events.Emit(true, struct {
+				Type  string `json:"type"`
+				Month string `json:"month"`
+				Games int    `json:"games"`
+			}{"fetch", parts[len(parts)-2] + "/" + parts[len(parts)-1], len(games)})
+		} else {
+			fmt.Printf("  Fetched %d games from %s/%s\n", len(games), parts[len(parts)-2], parts[len(parts)-1])
+		}
+		allGames = append(allGames, games...)
+	}
+	if len(allGames) > *maxGames {
+		allGames = allGames[:*maxGames]
+	}
+	fetchTime := time.Since(fetchStart)
+	if !*jsonOutput {
+		fmt.Printf("Fetched %d games in %.2fs\n\n", len(allGames), fetchTime.Seconds())
+		fmt.Println("Analyzing games...")
+	}
+	pool, err := NewEnginePool(*workers, *sfThreads, *depth, defaultMaxAnalysesPerEngine)
+	if err != nil {
+		fmt.Printf("Failed to start engine pool: %v\n", err)
+		return
+	}
+	defer pool.Close()
+
+	analysisStart := time.Now()
+	var completed int64
+	total := len(allGames)
+
+	type result struct {
+		wa, ba       float64
+		moves        int
+		white, black string
+		ok           bool
+		wallTime     time.Duration
+		parseTime    time.Duration
+		analyzeTime  time.Duration
+	}
+	results := make(chan result, total)
+	var wg sync.WaitGroup
+
+	for _, g := range allGames {
+		wg.Add(1)
+		go func(game GameData) {
+			defer wg.Done()
+			gameStart := time.Now()
+			metrics.InFlightWorkers.Inc()
+			defer metrics.InFlightWorkers.Dec()
+
+			pe, err := pool.Acquire()
+			if err != nil {
+				results <- result{}
+				return
+			}
+			wa, ba, m, w, b, ok, parseTime, analyzeTime := analyzeGame(pe, game, *username, cache)
+			pool.Release(pe)
+			results <- result{wa, ba, m, w, b, ok, time.Since(gameStart), parseTime, analyzeTime}
+
+			if *jsonOutput && ok {
+				events.Emit(true, struct {
+					Type      string  `json:"type"`
+					White     string  `json:"white"`
+					Black     string  `json:"black"`
+					WhiteAcc  float64 `json:"white_acc"`
+					BlackAcc  float64 `json:"black_acc"`
+					Moves     int     `json:"moves"`
+					ParseMs   float64 `json:"parse_ms"`
+					AnalyzeMs float64 `json:"analyze_ms"`
+				}{"game", w, b, wa, ba, m, float64(parseTime.Microseconds()) / 1000, float64(analyzeTime.Microseconds()) / 1000})
+			}
+
+			c := atomic.AddInt64(&completed, 1)
+			gps := float64(c) / time.Since(analysisStart).Seconds()
+			metrics.GamesPerSecond.Set(gps)
+			if *jsonOutput {
+				if c%10 == 0 || c == int64(total) {
+					events.Emit(true, struct {
+						Type      string  `json:"type"`
+						Completed int64   `json:"completed"`
+						Total     int     `json:"total"`
+						GPS       float64 `json:"gps"`
+					}{"progress", c, total, gps})
+				}
+			} else if c%10 == 0 || c == int64(total) {
+				fmt.Printf("  Analyzed %d/%d games (%.2f games/sec)\n", c, total, gps)
+			}
+		}(g)
+	}
+	go func() { wg.Wait(); close(results) }()
+
+	var userAcc []float64
+	var totalMoves, analyzed int
+	var gameDurations, moveDurations, parseDurations, analyzeDurations []time.Duration
+	target := strings.ToLower(*username)
+	for r := range results {
+		if r.ok {
+			analyzed++
+			totalMoves += r.moves
+			if r.white == target {
+				userAcc = append(userAcc, r.wa)
+			} else {
+				userAcc = append(userAcc, r.ba)
+			}
+			gameDurations = append(gameDurations, r.wallTime)
+			parseDurations = append(parseDurations, r.parseTime)
+			analyzeDurations = append(analyzeDurations, r.analyzeTime)
+			if r.moves > 0 {
+				moveDurations = append(moveDurations, r.wallTime/time.Duration(r.moves))
+			}
+		}
+	}
+	analysisTime := time.Since(analysisStart)
+
+	avg := 0.0
+	if len(userAcc) > 0 {
+		for _, a := range userAcc {
+			avg += a
+		}
+		avg /= float64(len(userAcc))
+	}
+
+	cacheHitRate := 0.0
+	if cache != nil {
+		cacheHitRate = cache.HitRate()
+	}
+
+	if *jsonOutput {
+		msPercentiles := func(durs []time.Duration) [6]float64 {
+			min, p50, p90, p95, p99, max := stats.Percentiles(durs)
+			return [6]float64{
+				float64(min.Microseconds()) / 1000,
+				float64(p50.Microseconds()) / 1000,
+				float64(p90.Microseconds()) / 1000,
+				float64(p95.Microseconds()) / 1000,
+				float64(p99.Microseconds()) / 1000,
+				float64(max.Microseconds()) / 1000,
+			}
+		}
+		events.Emit(true, struct {
+			Type            string                `json:"type"`
+			GamesAnalyzed   int                   `json:"games_analyzed"`
+			TotalMoves      int                   `json:"total_moves"`
+			AvgAccuracy     float64               `json:"avg_accuracy"`
+			FetchSeconds    float64               `json:"fetch_seconds"`
+			AnalysisSeconds float64               `json:"analysis_seconds"`
+			GamesPerSecond  float64               `json:"games_per_second"`
+			MovesPerSecond  float64               `json:"moves_per_second"`
+			CacheHitRate    float64               `json:"cache_hit_rate"`
+			PercentilesMs   map[string][6]float64 `json:"percentiles_ms"`
+		}{
+			Type:            "summary",
+			GamesAnalyzed:   analyzed,
+			TotalMoves:      totalMoves,
+			AvgAccuracy:     avg,
+			FetchSeconds:    fetchTime.Seconds(),
+			AnalysisSeconds: analysisTime.Seconds(),
+			GamesPerSecond:  float64(analyzed) / analysisTime.Seconds(),
+			MovesPerSecond:  float64(totalMoves) / analysisTime.Seconds(),
+			CacheHitRate:    cacheHitRate,
+			PercentilesMs: map[string][6]float64{
+				"per_game":       msPercentiles(gameDurations),
+				"per_move":       msPercentiles(moveDurations),
+				"pgn_parse":      msPercentiles(parseDurations),
+				"engine_analyze": msPercentiles(analyzeDurations),
+			},
+		})
+		return
+	}
+
+	fmt.Println("\nResults")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Games analyzed: %d\n", analyzed)
+	fmt.Printf("Total moves: %d\n", totalMoves)
+	fmt.Printf("Average accuracy for %s: %.2f%%\n", *username, avg)
+	fmt.Println("\nPerformance")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Fetch time: %.2fs\n", fetchTime.Seconds())
+	fmt.Printf("Analysis time: %.2fs\n", analysisTime.Seconds())
+	fmt.Printf("Total time: %.2fs\n", fetchTime.Seconds()+analysisTime.Seconds())
+	fmt.Printf("Games per second: %.4f\n", float64(analyzed)/analysisTime.Seconds())
+	fmt.Printf("Moves per second: %.2f\n", float64(totalMoves)/analysisTime.Seconds())
+	if cache != nil {
+		fmt.Printf("Cache hit rate: %.2f%%\n", cacheHitRate*100)
+	}
+
+	fmt.Println("\nLatency percentiles")
+	fmt.Println(strings.Repeat("=", 50))
+	printLatencyRow := func(label string, durs []time.Duration) {
+		min, p50, p90, p95, p99, max := stats.Percentiles(durs)
+		fmt.Printf("%-16s min=%-8s p50=%-8s p90=%-8s p95=%-8s p99=%-8s max=%s\n",
+			label, min, p50, p90, p95, p99, max)
+	}
+	printLatencyRow("per-game", gameDurations)
+	printLatencyRow("per-move", moveDurations)
+	printLatencyRow("PGN parse", parseDurations)
+	printLatencyRow("engine analyze", analyzeDurations)
+}
@@ -0,0 +1,112 @@
+package main
+
+import "fmt"
+
+// defaultMaxAnalysesPerEngine bounds how many positions a pooled engine
+// analyzes before it is proactively respawned, guarding against any slow
+// internal state growth inside Stockfish over a very long run.
+const defaultMaxAnalysesPerEngine = 20000
+
+// pooledEngine wraps a StockfishEngine with the bookkeeping EnginePool needs
+// to decide when to respawn it. A nil StockfishEngine marks a slot whose
+// last respawn attempt failed; Acquire retries it rather than dropping the
+// slot from the pool.
+type pooledEngine struct {
+	*StockfishEngine
+	analyses int
+}
+
+// EnginePool holds a fixed set of pre-warmed Stockfish engines so
+// analyzeGame can borrow one instead of paying UCI handshake + process
+// spawn cost per game.
+type EnginePool struct {
+	engines     chan *pooledEngine
+	threads     int
+	depth       int
+	maxAnalyses int
+}
+
+// NewEnginePool spawns `size` engines up front, each configured with
+// threads/depth, so the pool is fully warm before the analysis loop starts.
+func NewEnginePool(size, threads, depth, maxAnalyses int) (*EnginePool, error) {
+	if maxAnalyses <= 0 {
+		maxAnalyses = defaultMaxAnalysesPerEngine
+	}
+	p := &EnginePool{
+		engines:     make(chan *pooledEngine, size),
+		threads:     threads,
+		depth:       depth,
+		maxAnalyses: maxAnalyses,
+	}
+	for i := 0; i < size; i++ {
+		e, err := NewStockfishEngine(threads, depth)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.engines <- &pooledEngine{StockfishEngine: e}
+	}
+	return p, nil
+}
+
+// respawn replaces e with a freshly spawned engine. On failure it puts a
+// poisoned placeholder back on the channel so the slot stays in the pool
+// and a later Acquire retries the respawn, instead of the pool silently
+// losing capacity forever.
+func (p *EnginePool) respawn(e *pooledEngine) (*pooledEngine, error) {
+	if e.StockfishEngine != nil {
+		e.quit()
+	}
+	fresh, err := NewStockfishEngine(p.threads, p.depth)
+	if err != nil {
+		p.engines <- &pooledEngine{analyses: p.maxAnalyses}
+		return nil, fmt.Errorf("respawning engine: %w", err)
+	}
+	return &pooledEngine{StockfishEngine: fresh}, nil
+}
+
+// Acquire borrows an engine and resets its UCI state for a new game via a
+// single ucinewgame/isready round trip, which doubles as a health check: a
+// broken pipe there means the engine died between games, so Acquire quits
+// and respawns it before handing it out. An engine that has seen too many
+// analyses is respawned unconditionally instead of being probed first.
+func (p *EnginePool) Acquire() (*pooledEngine, error) {
+	e := <-p.engines
+
+	if e.StockfishEngine == nil || e.analyses >= p.maxAnalyses {
+		var err error
+		if e, err = p.respawn(e); err != nil {
+			return nil, err
+		}
+	}
+
+	e.send("ucinewgame")
+	e.send("isready")
+	if !e.waitForReady("readyok") {
+		var err error
+		if e, err = p.respawn(e); err != nil {
+			return nil, err
+		}
+		e.send("ucinewgame")
+		e.send("isready")
+		e.waitForReady("readyok")
+	}
+
+	return e, nil
+}
+
+// Release returns an engine to the pool for reuse.
+func (p *EnginePool) Release(e *pooledEngine) {
+	p.engines <- e
+}
+
+// Close quits every engine in the pool. Only safe once no goroutine still
+// holds an engine borrowed from it.
+func (p *EnginePool) Close() {
+	close(p.engines)
+	for e := range p.engines {
+		if e.StockfishEngine != nil {
+			e.quit()
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/notnil/chess"
+
+	"github.com/Bot-Rakshit/chess-bench/internal/events"
+	"github.com/Bot-Rakshit/chess-bench/internal/metrics"
+	"github.com/Bot-Rakshit/chess-bench/internal/stats"
+)
+
+type ArchivesResp struct {
+	Archives []string `json:"archives"`
+}
+type GamesResp struct {
+	Games []GameD `json:"games"`
+}
+type GameD struct {
+	PGN string `json:"pgn"`
+}
+
+func fetchArch(username string) []string {
+	client := &http.Client{Timeout: 30 * time.Second}
+	req, _ := http.NewRequest("GET", fmt.Sprintf("https://api.chess.com/pub/player/%s/games/archives", username), nil)
+	req.Header.Set("User-Agent", "ChessBenchmark/1.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	var data ArchivesResp
+	json.NewDecoder(resp.Body).Decode(&data)
+	return data.Archives
+}
+
+func fetchG(url string) []GameD {
+	client := &http.Client{Timeout: 60 * time.Second}
+	req, _ := http.NewRequest("GET", url, nil)
+	req.Header.Set("User-Agent", "ChessBenchmark/1.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	var data GamesResp
+	json.NewDecoder(resp.Body).Decode(&data)
+	return data.Games
+}
+
+func parseGame(pgn string) (int, int) {
+	if pgn == "" {
+		return 0, 0
+	}
+	pgnGame, err := chess.PGN(strings.NewReader(pgn))
+	if err != nil {
+		return 0, 0
+	}
+	game := chess.NewGame(pgnGame)
+	moves := game.Moves()
+	mc, pc := 0, 1
+	pos := chess.NewGame()
+	for _, mv := range moves {
+		pos.Move(mv)
+		mc++
+		pc++
+		_ = pos.Position().String()
+	}
+	return mc, pc
+}
+
+func main() {
+	username := flag.String("username", "hikaru", "")
+	maxGames := flag.Int("games", 1000, "")
+	workers := flag.Int("workers", 4, "")
+	metricsAddr := flag.String("metrics-addr", "", "Serve Prometheus /metrics and /debug/pprof/* on this address (disabled if empty)")
+	jsonOutput := flag.Bool("json", false, "Emit an NDJSON event stream on stdout instead of human-readable text")
+	flag.Parse()
+
+	if *metricsAddr != "" {
+		go metrics.Serve(*metricsAddr)
+	}
+	if flag.NArg() >= 1 {
+		*username = flag.Arg(0)
+	}
+	if flag.NArg() >= 2 {
+		fmt.Sscanf(flag.Arg(1), "%d", maxGames)
+	}
+
+	if *jsonOutput {
+		events.Emit(true, struct {
+			Type     string `json:"type"`
+			Username string `json:"username"`
+			MaxGames int    `json:"max_games"`
+			Workers  int    `json:"workers"`
+		}{"config", *username, *maxGames, *workers})
+	} else {
+		fmt.Println("Go PGN Parsing Benchmark")
+		fmt.Println(strings.Repeat("=", 50))
+		fmt.Printf("Library: notnil/chess\n")
+		fmt.Printf("Username: %s\n", *username)
+		fmt.Printf("Max games: %d\n", *maxGames)
+		fmt.Printf("Workers: %d\n\n", *workers)
+		fmt.Println("Fetching games...")
+	}
+
+	fetchStart := time.Now()
+	archives := fetchArch(*username)
+	for i, j := 0, len(archives)-1; i < j; i, j = i+1, j-1 {
+		archives[i], archives[j] = archives[j], archives[i]
+	}
+
+	var allPgns []string
+	for _, url := range archives {
+		if len(allPgns) >= *maxGames {
+			break
+		}
+		games := fetchG(url)
+		parts := strings.Split(url, "/")
+		metrics.GamesFetchedTotal.Add(float64(len(games)))
+		if *jsonOutput {
+			events.Emit(true, struct {
+				Type  string `json:"type"`
+				Month string `json:"month"`
+				Games int    `json:"games"`
+			}{"fetch", parts[len(parts)-2] + "/" + parts[len(parts)-1], len(games)})
+		} else {
+			fmt.Printf("  Fetched %d games from %s/%s\n", len(games), parts[len(parts)-2], parts[len(parts)-1])
+		}
+		for _, g := range games {
+			if g.PGN != "" {
+				allPgns = append(allPgns, g.PGN)
+			}
+		}
+	}
+	if len(allPgns) > *maxGames {
+		allPgns = allPgns[:*maxGames]
+	}
+	if !*jsonOutput {
+		fmt.Printf("Fetched %d games in %.2fs\n\n", len(allPgns), time.Since(fetchStart).Seconds())
+		fmt.Println("Parsing PGNs...")
+	}
+	parseStart := time.Now()
+	var completed int64
+	total := len(allPgns)
+
+	type res struct {
+		m, p     int
+		gameTime time.Duration
+	}
+	results := make(chan res, total)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, *workers)
+
+	for _, pgn := range allPgns {
+		wg.Add(1)
+		go func(p string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			metrics.InFlightWorkers.Inc()
+			defer metrics.InFlightWorkers.Dec()
+			gameStart := time.Now()
+			m, pos := parseGame(p)
+			gameTime := time.Since(gameStart)
+			metrics.PgnParseSeconds.Observe(gameTime.Seconds())
+			if m > 0 {
+				metrics.GamesParsedTotal.Inc()
+			}
+			results <- res{m, pos, gameTime}
+
+			if *jsonOutput && m > 0 {
+				events.Emit(true, struct {
+					Type    string  `json:"type"`
+					Moves   int     `json:"moves"`
+					ParseMs float64 `json:"parse_ms"`
+				}{"game", m, float64(gameTime.Microseconds()) / 1000})
+			}
+
+			c := atomic.AddInt64(&completed, 1)
+			gps := float64(c) / time.Since(parseStart).Seconds()
+			metrics.GamesPerSecond.Set(gps)
+			if *jsonOutput {
+				if c%100 == 0 || c == int64(total) {
+					events.Emit(true, struct {
+						Type      string  `json:"type"`
+						Completed int64   `json:"completed"`
+						Total     int     `json:"total"`
+						GPS       float64 `json:"gps"`
+					}{"progress", c, total, gps})
+				}
+			} else if c%100 == 0 || c == int64(total) {
+				fmt.Printf("  Parsed %d/%d games (%.2f games/sec)\n", c, total, gps)
+			}
+		}(pgn)
+	}
+	go func() { wg.Wait(); close(results) }()
+
+	var tm, tp, parsed int
+	var gameDurations, moveDurations []time.Duration
+	for r := range results {
+		if r.m > 0 {
+			tm += r.m
+			tp += r.p
+			parsed++
+			gameDurations = append(gameDurations, r.gameTime)
+			moveDurations = append(moveDurations, r.gameTime/time.Duration(r.m))
+		}
+	}
+	parseTime := time.Since(parseStart)
+
+	if *jsonOutput {
+		msPercentiles := func(durs []time.Duration) [6]float64 {
+			min, p50, p90, p95, p99, max := stats.Percentiles(durs)
+			return [6]float64{
+				float64(min.Microseconds()) / 1000,
+				float64(p50.Microseconds()) / 1000,
+				float64(p90.Microseconds()) / 1000,
+				float64(p95.Microseconds()) / 1000,
+				float64(p99.Microseconds()) / 1000,
+				float64(max.Microseconds()) / 1000,
+			}
+		}
+		events.Emit(true, struct {
+			Type           string                `json:"type"`
+			GamesParsed    int                   `json:"games_parsed"`
+			TotalMoves     int                   `json:"total_moves"`
+			ParseSeconds   float64               `json:"parse_seconds"`
+			GamesPerSecond float64               `json:"games_per_second"`
+			MovesPerSecond float64               `json:"moves_per_second"`
+			PercentilesMs  map[string][6]float64 `json:"percentiles_ms"`
+		}{
+			Type:           "summary",
+			GamesParsed:    parsed,
+			TotalMoves:     tm,
+			ParseSeconds:   parseTime.Seconds(),
+			GamesPerSecond: float64(parsed) / parseTime.Seconds(),
+			MovesPerSecond: float64(tm) / parseTime.Seconds(),
+			PercentilesMs: map[string][6]float64{
+				"per_game": msPercentiles(gameDurations),
+				"per_move": msPercentiles(moveDurations),
+			},
+		})
+		return
+	}
+
+	fmt.Println("\nResults")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Games parsed: %d\n", parsed)
+	fmt.Printf("Total moves: %d\n", tm)
+	fmt.Println("\nPerformance")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Parse time: %.4fs\n", parseTime.Seconds())
+	fmt.Printf("Games per second: %.2f\n", float64(parsed)/parseTime.Seconds())
+	fmt.Printf("Moves per second: %.2f\n", float64(tm)/parseTime.Seconds())
+
+	fmt.Println("\nLatency percentiles")
+	fmt.Println(strings.Repeat("=", 50))
+	printLatencyRow := func(label string, durs []time.Duration) {
+		min, p50, p90, p95, p99, max := stats.Percentiles(durs)
+		fmt.Printf("%-10s min=%-8s p50=%-8s p90=%-8s p95=%-8s p99=%-8s max=%s\n",
+			label, min, p50, p90, p95, p99, max)
+	}
+	printLatencyRow("per-game", gameDurations)
+	printLatencyRow("per-move", moveDurations)
+}
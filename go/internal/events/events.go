@@ -0,0 +1,24 @@
+// Package events implements the NDJSON event stream shared by both
+// benchmark binaries' --json mode.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Emit marshals v as a single NDJSON line to stdout when jsonMode is true.
+// Each call site passes an anonymous struct carrying its own "type" field,
+// so the wire format stays a flat, diffable event stream (inspired by
+// buildkit's status reporting) without a shared event hierarchy to
+// maintain.
+func Emit(jsonMode bool, v interface{}) {
+	if !jsonMode {
+		return
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	fmt.Println(string(b))
+}
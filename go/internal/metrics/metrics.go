@@ -0,0 +1,59 @@
+// Package metrics exposes the Prometheus counters/histograms/gauges and the
+// pprof + /metrics HTTP server shared by both benchmark binaries. Mirrors
+// the lotus-bench importer's pattern of a background pprof + Prometheus
+// port that users can point Grafana or `go tool pprof` at without touching
+// a program's normal stdout output.
+package metrics
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	_ "net/http/pprof"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	GamesFetchedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "games_fetched_total",
+		Help: "Total games fetched from the chess.com archive API.",
+	})
+	GamesParsedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "games_parsed_total",
+		Help: "Total games successfully parsed into a move list.",
+	})
+	PositionsEvaluatedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "positions_evaluated_total",
+		Help: "Total positions sent to Stockfish for WDL evaluation.",
+	})
+	StockfishAnalyzeSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "stockfish_analyze_seconds",
+		Help:    "Time spent analyzing a single position with Stockfish.",
+		Buckets: prometheus.DefBuckets,
+	})
+	PgnParseSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pgn_parse_seconds",
+		Help:    "Time spent parsing a single game's PGN.",
+		Buckets: prometheus.DefBuckets,
+	})
+	InFlightWorkers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "in_flight_workers",
+		Help: "Number of games currently being analyzed.",
+	})
+	GamesPerSecond = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "games_per_second",
+		Help: "Current throughput in games analyzed per second.",
+	})
+)
+
+// Serve starts a background HTTP server exposing /debug/pprof/* (registered
+// on DefaultServeMux by the blank net/http/pprof import) and /metrics. It
+// never returns; call it in its own goroutine.
+func Serve(addr string) {
+	http.Handle("/metrics", promhttp.Handler())
+	fmt.Printf("Serving metrics and pprof on http://%s\n", addr)
+	log.Println(http.ListenAndServe(addr, nil))
+}
@@ -0,0 +1,26 @@
+// Package stats provides small local statistics helpers shared by the
+// benchmark binaries.
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// Percentiles sorts durations and returns min, p50, p90, p95, p99, max. It
+// implements its own index-into-sorted-slice percentile (no new dependency)
+// since callers only need a handful of fixed percentiles.
+func Percentiles(durations []time.Duration) (min, p50, p90, p95, p99, max time.Duration) {
+	if len(durations) == 0 {
+		return
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	at := func(p float64) time.Duration {
+		i := int(p * float64(len(sorted)-1))
+		return sorted[i]
+	}
+	return sorted[0], at(0.50), at(0.90), at(0.95), at(0.99), sorted[len(sorted)-1]
+}
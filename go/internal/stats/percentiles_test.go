@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPercentilesEmpty(t *testing.T) {
+	min, p50, p90, p95, p99, max := Percentiles(nil)
+	if min != 0 || p50 != 0 || p90 != 0 || p95 != 0 || p99 != 0 || max != 0 {
+		t.Fatalf("expected all zero values for an empty input, got min=%v p50=%v p90=%v p95=%v p99=%v max=%v",
+			min, p50, p90, p95, p99, max)
+	}
+}
+
+func TestPercentilesSingleElement(t *testing.T) {
+	want := 7 * time.Millisecond
+	min, p50, p90, p95, p99, max := Percentiles([]time.Duration{want})
+	for name, got := range map[string]time.Duration{
+		"min": min, "p50": p50, "p90": p90, "p95": p95, "p99": p99, "max": max,
+	} {
+		if got != want {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestPercentilesBoundaries(t *testing.T) {
+	// 10 elements, deliberately out of order, values 1ms..10ms.
+	durs := make([]time.Duration, 10)
+	for i := range durs {
+		durs[i] = time.Duration(10-i) * time.Millisecond
+	}
+
+	min, p50, p90, p95, p99, max := Percentiles(durs)
+	wantMin := 1 * time.Millisecond
+	wantP50 := 5 * time.Millisecond
+	wantP90 := 9 * time.Millisecond
+	wantMax := 10 * time.Millisecond
+
+	if min != wantMin {
+		t.Errorf("min = %v, want %v", min, wantMin)
+	}
+	if p50 != wantP50 {
+		t.Errorf("p50 = %v, want %v", p50, wantP50)
+	}
+	if p90 != wantP90 {
+		t.Errorf("p90 = %v, want %v", p90, wantP90)
+	}
+	if p95 != wantP90 {
+		t.Errorf("p95 = %v, want %v", p95, wantP90)
+	}
+	if p99 != wantP90 {
+		t.Errorf("p99 = %v, want %v", p99, wantP90)
+	}
+	if max != wantMax {
+		t.Errorf("max = %v, want %v", max, wantMax)
+	}
+}